@@ -0,0 +1,133 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+)
+
+// abortIndex is the index Context.index is set to by Abort, so that
+// subsequent calls to Next stop short of actually running any more handlers.
+// It sits near the top of the int8 range rather than at a small fixed
+// constant so a middleware-heavy chain (auth, logging, CORS, rate limiting,
+// tracing, ...) isn't artificially capped.
+const abortIndex int8 = math.MaxInt8 >> 1
+
+// Context is the most important part of core. It is passed to every
+// RouterHandler, carries the request/response pair and the URL params
+// matched for the current route, and is responsible for translating a
+// handler's (interface{}, error) return value into an HTTP response.
+type Context struct {
+	Writer  http.ResponseWriter
+	Request *http.Request
+
+	Params   map[string]string
+	handlers RouterHandlerChain
+	index    int8
+	engine   *Engine
+
+	data      interface{}
+	err       error
+	dataIndex int8
+}
+
+func newContext(engine *Engine, w http.ResponseWriter, req *http.Request, params map[string]string, handlers RouterHandlerChain) *Context {
+	return &Context{
+		Writer:    w,
+		Request:   req,
+		Params:    params,
+		handlers:  handlers,
+		index:     -1,
+		dataIndex: -1,
+		engine:    engine,
+	}
+}
+
+// Param returns the value of the URL param.
+// It is a shortcut for c.Params[key].
+func (c *Context) Param(key string) string {
+	return c.Params[key]
+}
+
+// run starts the handler chain and writes whatever the last handler that
+// ran returned.
+func (c *Context) run() {
+	c.Next()
+	c.writeResponse(c.data, c.err)
+}
+
+// Next should be used only inside middleware. It executes the pending
+// handlers in the chain of the current route. The data/error that will
+// ultimately be written to the response is whichever handler ran deepest in
+// the chain (normally the real handler, last in the chain); a middleware
+// that calls Next and then returns its own (nil, nil) afterwards - the usual
+// pre/post-logic idiom - does not clobber that result.
+func (c *Context) Next() {
+	c.index++
+	for c.index < int8(len(c.handlers)) {
+		handlerIndex := c.index
+		data, err := c.handlers[handlerIndex](c)
+		if handlerIndex >= c.dataIndex {
+			c.data, c.err = data, err
+			c.dataIndex = handlerIndex
+		}
+		c.index++
+	}
+}
+
+// IsAborted returns true if the current context was aborted.
+func (c *Context) IsAborted() bool {
+	return c.index >= abortIndex
+}
+
+// Abort prevents pending handlers from being called. Note that this will
+// not stop the current handler. Use IsAborted to check if a handler after
+// the current one should run or not. It is typically used to abort a chain
+// of handlers after authentication or validation fails.
+func (c *Context) Abort() {
+	c.index = abortIndex
+}
+
+// AbortWithStatus calls Abort and writes the given status code to the
+// response header.
+func (c *Context) AbortWithStatus(code int) {
+	c.Writer.WriteHeader(code)
+	c.Abort()
+}
+
+// writeResponse turns a handler's return value into an HTTP response. A nil
+// data and nil error means the handler already wrote the response itself
+// (e.g. by serving a static file), so nothing further is written.
+func (c *Context) writeResponse(data interface{}, err error) {
+	if err != nil {
+		c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		c.Writer.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(c.Writer).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	if data == nil {
+		return
+	}
+	c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(c.Writer).Encode(data)
+}
+
+// File writes the specified file into the body stream in an efficient way.
+func (c *Context) File(filepath string) {
+	http.ServeFile(c.Writer, c.Request, filepath)
+}
+
+// FileFromFS writes the specified file from http.FileSystem into the body stream.
+func (c *Context) FileFromFS(filepath string, fs http.FileSystem) {
+	defer func(old string) {
+		c.Request.URL.Path = old
+	}(c.Request.URL.Path)
+
+	c.Request.URL.Path = filepath
+
+	http.FileServer(fs).ServeHTTP(c.Writer, c.Request)
+}
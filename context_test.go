@@ -0,0 +1,133 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestContextNextMiddlewarePostReturn exercises the onion-style pre/post
+// middleware idiom: a middleware calls c.Next() to run the rest of the
+// chain, then runs its own post-logic and returns (nil, nil). The real
+// handler's response must survive that, not be blanked out by the
+// middleware's own return value as the call stack unwinds.
+func TestContextNextMiddlewarePostReturn(t *testing.T) {
+	var postRan bool
+	mw := func(c *Context) (interface{}, error) {
+		c.Next()
+		postRan = true
+		return nil, nil
+	}
+	real := func(c *Context) (interface{}, error) {
+		return "real-response", nil
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := newContext(nil, w, req, nil, RouterHandlerChain{mw, real})
+	c.run()
+
+	if !postRan {
+		t.Fatal("expected middleware's post-Next logic to run")
+	}
+	if got, want := w.Body.String(), "\"real-response\"\n"; got != want {
+		t.Fatalf("response body = %q, want %q (real handler's result was clobbered)", got, want)
+	}
+}
+
+// TestContextAbortStopsPendingHandlers ensures Abort prevents handlers after
+// the current one in the chain from running, without affecting the current
+// handler.
+func TestContextAbortStopsPendingHandlers(t *testing.T) {
+	var ranSecond, ranThird bool
+	first := func(c *Context) (interface{}, error) {
+		c.Abort()
+		return nil, nil
+	}
+	second := func(c *Context) (interface{}, error) {
+		ranSecond = true
+		return nil, nil
+	}
+	third := func(c *Context) (interface{}, error) {
+		ranThird = true
+		return nil, nil
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := newContext(nil, w, req, nil, RouterHandlerChain{first, second, third})
+	c.run()
+
+	if ranSecond || ranThird {
+		t.Fatal("expected Abort to stop all handlers after the current one")
+	}
+	if !c.IsAborted() {
+		t.Fatal("expected IsAborted to report true after Abort")
+	}
+}
+
+// TestContextAbortWithStatusWritesHeader ensures AbortWithStatus both aborts
+// the chain and writes the given status code.
+func TestContextAbortWithStatusWritesHeader(t *testing.T) {
+	var ranNext bool
+	mw := func(c *Context) (interface{}, error) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return nil, nil
+	}
+	next := func(c *Context) (interface{}, error) {
+		ranNext = true
+		return nil, nil
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := newContext(nil, w, req, nil, RouterHandlerChain{mw, next})
+	c.run()
+
+	if ranNext {
+		t.Fatal("expected AbortWithStatus to stop the next handler from running")
+	}
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+// TestRouterGroupAllowsMoreThanFourHandlers ensures the handler chain limit
+// was raised from the old hard cap of 4, letting middleware-heavy setups
+// (auth + logging + CORS + rate limit + tracing + real handler) register.
+func TestRouterGroupAllowsMoreThanFourHandlers(t *testing.T) {
+	noop := func(c *Context) (interface{}, error) { return nil, nil }
+	handlers := make([]RouterHandler, 6)
+	for i := range handlers {
+		handlers[i] = noop
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("unexpected panic registering 6 handlers: %v", r)
+		}
+	}()
+	New().GET("/many", handlers...)
+}
+
+// TestRouterGroupPanicsNearInt8HandlerLimit ensures combineHandlers still
+// panics once a chain approaches the real int8 abortIndex limit, rather than
+// silently overflowing.
+func TestRouterGroupPanicsNearInt8HandlerLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic registering a chain near the int8 abortIndex limit")
+		}
+	}()
+
+	noop := func(c *Context) (interface{}, error) { return nil, nil }
+	handlers := make([]RouterHandler, 64)
+	for i := range handlers {
+		handlers[i] = noop
+	}
+	New().GET("/too-many", handlers...)
+}
@@ -0,0 +1,229 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"html/template"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// routeEntry is a single registered route: the method it was registered
+// under, its original path (kept for Routes()) and the path split into
+// segments so it can be matched against an incoming request.
+type routeEntry struct {
+	method      string
+	path        string
+	segments    []string
+	handlers    RouterHandlerChain
+	handlerName string
+}
+
+// RouteInfo represents a request route's specification which contains the
+// method, path, the real handler's name and the size of its handler chain.
+type RouteInfo struct {
+	Method        string
+	Path          string
+	HandlerName   string
+	HandlersCount int
+}
+
+// DebugPrintRouteFunc, if set, is called once for every route registered,
+// letting operators dump the route table on boot or export it to an
+// OpenAPI/Swagger generator.
+var DebugPrintRouteFunc func(httpMethod, absolutePath, handlerName string, nuHandlers int)
+
+// Engine is the framework's instance, it contains the muxer, middleware and
+// configuration settings. Create an instance of Engine by using New().
+type Engine struct {
+	RouterGroup
+
+	routes       map[string][]*routeEntry
+	noRoute      RouterHandlerChain
+	noMethod     RouterHandlerChain
+	htmlTemplate *template.Template
+	providers    map[reflect.Type]provider
+}
+
+var _ IRouter = &Engine{}
+var _ http.Handler = &Engine{}
+
+// New returns a new blank Engine instance without any middleware attached.
+func New() *Engine {
+	engine := &Engine{
+		routes:    make(map[string][]*routeEntry),
+		providers: make(map[reflect.Type]provider),
+	}
+	engine.RouterGroup.engine = engine
+	engine.RouterGroup.basePath = "/"
+	engine.RouterGroup.root = true
+	return engine
+}
+
+func (engine *Engine) addRoute(httpMethod, path string, handlers RouterHandlerChain, handlerName string) {
+	if len(handlers) == 0 {
+		panic("there must be at least one handler")
+	}
+	engine.routes[httpMethod] = append(engine.routes[httpMethod], &routeEntry{
+		method:      httpMethod,
+		path:        path,
+		segments:    splitPath(path),
+		handlers:    handlers,
+		handlerName: handlerName,
+	})
+
+	if DebugPrintRouteFunc != nil {
+		DebugPrintRouteFunc(httpMethod, path, handlerName, len(handlers))
+	}
+}
+
+// Routes returns a slice of registered routes, including some useful
+// information such as the HTTP method, path and handler name.
+func (engine *Engine) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0)
+	for method, entries := range engine.routes {
+		for _, entry := range entries {
+			routes = append(routes, RouteInfo{
+				Method:        method,
+				Path:          entry.path,
+				HandlerName:   entry.handlerName,
+				HandlersCount: len(entry.handlers),
+			})
+		}
+	}
+	return routes
+}
+
+// ServeHTTP makes the Engine implement the http.Handler interface.
+func (engine *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	for _, entry := range engine.routes[req.Method] {
+		if params, ok := matchPath(entry.segments, req.URL.Path); ok {
+			c := newContext(engine, w, req, params, entry.handlers)
+			c.run()
+			return
+		}
+	}
+
+	for method, entries := range engine.routes {
+		if method == req.Method {
+			continue
+		}
+		for _, entry := range entries {
+			if _, ok := matchPath(entry.segments, req.URL.Path); ok {
+				engine.serveError(w, req, engine.noMethod, http.StatusMethodNotAllowed)
+				return
+			}
+		}
+	}
+
+	engine.serveError(w, req, engine.noRoute, http.StatusNotFound)
+}
+
+func (engine *Engine) serveError(w http.ResponseWriter, req *http.Request, handlers RouterHandlerChain, code int) {
+	if len(handlers) == 0 {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+	c := newContext(engine, w, req, nil, handlers)
+	c.run()
+}
+
+// Run attaches the router to a http.Server and starts listening and serving
+// HTTP requests. It is a shortcut for http.ListenAndServe(addr, engine).
+func (engine *Engine) Run(addr string) error {
+	return http.ListenAndServe(addr, engine)
+}
+
+// NoRoute adds handlers for requests that don't match any route. By
+// default http.StatusNotFound is written if no handlers are registered.
+func (engine *Engine) NoRoute(handlers ...RouterHandler) {
+	engine.noRoute = handlers
+}
+
+// NoMethod adds handlers for requests matching a registered path but not
+// its method. By default http.StatusMethodNotAllowed is written if no
+// handlers are registered.
+func (engine *Engine) NoMethod(handlers ...RouterHandler) {
+	engine.noMethod = handlers
+}
+
+// LoadHTMLGlob loads HTML files identified by glob pattern and associates
+// the result with the engine's HTML renderer.
+func (engine *Engine) LoadHTMLGlob(pattern string) {
+	engine.SetHTMLTemplate(template.Must(template.ParseGlob(pattern)))
+}
+
+// SetHTMLTemplate associates a template with the engine's HTML renderer.
+func (engine *Engine) SetHTMLTemplate(tmpl *template.Template) {
+	engine.htmlTemplate = tmpl
+}
+
+// Register walks a detached group tree built with NewGroup and Children,
+// registering every route it declares. Paths are concatenated via joinPaths
+// and handler chains via combineHandlers, so a REST resource tree can be
+// declared once, up front, and attached to the engine in a single call.
+// Registration back-fills each node's basePath, Handlers and engine in
+// place, so the original *RouterGroup values the caller holds (e.g. root
+// itself) are left attached and usable afterwards, including via Routes().
+func (engine *Engine) Register(root *RouterGroup) {
+	engine.registerGroup(&engine.RouterGroup, root)
+}
+
+func (engine *Engine) registerGroup(parent, node *RouterGroup) {
+	node.basePath = joinPaths(parent.basePath, node.basePath)
+	node.Handlers = parent.combineHandlers(node.Handlers)
+	node.engine = engine
+
+	for _, pending := range node.pending {
+		absolutePath := joinPaths(node.basePath, pending.relativePath)
+		handlers := node.combineHandlers(pending.handlers)
+		engine.addRoute(pending.method, absolutePath, handlers, pending.handlerName)
+	}
+
+	for _, child := range node.children {
+		engine.registerGroup(node, child)
+	}
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return []string{}
+	}
+	return strings.Split(p, "/")
+}
+
+// matchPath checks whether requestPath satisfies the given route segments,
+// returning any `:param`/`*filepath` values captured along the way.
+func matchPath(segments []string, requestPath string) (map[string]string, bool) {
+	reqSegments := splitPath(requestPath)
+	params := make(map[string]string)
+
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "*") {
+			if i > len(reqSegments) {
+				return nil, false
+			}
+			params[seg[1:]] = "/" + strings.Join(reqSegments[i:], "/")
+			return params, true
+		}
+		if i >= len(reqSegments) {
+			return nil, false
+		}
+		if strings.HasPrefix(seg, ":") {
+			params[seg[1:]] = reqSegments[i]
+			continue
+		}
+		if seg != reqSegments[i] {
+			return nil, false
+		}
+	}
+
+	if len(segments) != len(reqSegments) {
+		return nil, false
+	}
+	return params, true
+}
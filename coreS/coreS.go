@@ -0,0 +1,113 @@
+// Package coreS provides package-level shortcuts around a lazily constructed
+// singleton *core.Engine, mirroring the ergonomics of http.HandleFunc for
+// small services and examples that would otherwise repeat the
+// "e := core.New(); e.GET(...)" boilerplate.
+package coreS
+
+import (
+	"html/template"
+	"sync"
+
+	core "github.com/HiLittleCat/goCore"
+)
+
+var (
+	once   sync.Once
+	engine *core.Engine
+)
+
+func instance() *core.Engine {
+	once.Do(func() {
+		engine = core.New()
+	})
+	return engine
+}
+
+// Engine returns the package-level singleton *core.Engine, constructing it
+// on first use. Use this as an escape hatch for anything not mirrored below.
+func Engine() *core.Engine {
+	return instance()
+}
+
+// Use registers middleware on the singleton engine's root group. Call it
+// before registering the first route so the middleware applies to every
+// route registered afterwards.
+func Use(middleware ...core.RouterHandler) *core.RouterGroup {
+	return instance().Use(middleware...)
+}
+
+// Group creates a router group on the singleton engine.
+func Group(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().Group(relativePath, handlers...)
+}
+
+// Handle registers a new request handle and middleware with the given path and method.
+func Handle(httpMethod, relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().Handle(httpMethod, relativePath, handlers...)
+}
+
+// GET is a shortcut for Handle("GET", path, handlers...).
+func GET(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().GET(relativePath, handlers...)
+}
+
+// POST is a shortcut for Handle("POST", path, handlers...).
+func POST(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().POST(relativePath, handlers...)
+}
+
+// PUT is a shortcut for Handle("PUT", path, handlers...).
+func PUT(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().PUT(relativePath, handlers...)
+}
+
+// DELETE is a shortcut for Handle("DELETE", path, handlers...).
+func DELETE(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().DELETE(relativePath, handlers...)
+}
+
+// PATCH is a shortcut for Handle("PATCH", path, handlers...).
+func PATCH(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().PATCH(relativePath, handlers...)
+}
+
+// HEAD is a shortcut for Handle("HEAD", path, handlers...).
+func HEAD(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().HEAD(relativePath, handlers...)
+}
+
+// OPTIONS is a shortcut for Handle("OPTIONS", path, handlers...).
+func OPTIONS(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().OPTIONS(relativePath, handlers...)
+}
+
+// Any registers a route that matches the methods in core.AnyMethods.
+func Any(relativePath string, handlers ...core.RouterHandler) *core.RouterGroup {
+	return instance().Any(relativePath, handlers...)
+}
+
+// NoRoute adds handlers for requests that don't match any route.
+func NoRoute(handlers ...core.RouterHandler) {
+	instance().NoRoute(handlers...)
+}
+
+// NoMethod adds handlers for requests matching a registered path but not its method.
+func NoMethod(handlers ...core.RouterHandler) {
+	instance().NoMethod(handlers...)
+}
+
+// LoadHTMLGlob loads HTML files identified by glob pattern onto the singleton engine.
+func LoadHTMLGlob(pattern string) {
+	instance().LoadHTMLGlob(pattern)
+}
+
+// SetHTMLTemplate associates a template with the singleton engine's HTML renderer.
+func SetHTMLTemplate(tmpl *template.Template) {
+	instance().SetHTMLTemplate(tmpl)
+}
+
+// Run attaches the singleton engine to a http.Server and starts listening
+// and serving HTTP requests on addr.
+func Run(addr string) error {
+	return instance().Run(addr)
+}
@@ -0,0 +1,45 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package coreS
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	core "github.com/HiLittleCat/goCore"
+)
+
+// TestPackageLevelShortcutsShareSingletonEngine exercises the coreS facade
+// end to end: middleware registered via Use before the first route applies
+// to a route registered afterwards via GET, and Engine() returns the same
+// singleton both shortcuts delegate to.
+func TestPackageLevelShortcutsShareSingletonEngine(t *testing.T) {
+	var ranMiddleware bool
+	Use(func(c *core.Context) (interface{}, error) {
+		ranMiddleware = true
+		c.Next()
+		return nil, nil
+	})
+
+	GET("/coreS-ping", func(c *core.Context) (interface{}, error) {
+		return "pong", nil
+	})
+
+	if Engine() != instance() {
+		t.Fatal("Engine() did not return the package singleton")
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/coreS-ping", nil)
+	Engine().ServeHTTP(w, req)
+
+	if !ranMiddleware {
+		t.Fatal("expected middleware registered via Use before the first route to run")
+	}
+	if got, want := w.Body.String(), "\"pong\"\n"; got != want {
+		t.Fatalf("response body = %q, want %q", got, want)
+	}
+}
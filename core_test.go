@@ -0,0 +1,66 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import "testing"
+
+// TestEngineRoutesReportsHandlerNameAndCount ensures Engine.Routes()
+// populates RouteInfo with the method, path, the real (last) handler's
+// fully-qualified name, and the size of its handler chain.
+func TestEngineRoutesReportsHandlerNameAndCount(t *testing.T) {
+	mw := func(c *Context) (interface{}, error) { return nil, nil }
+	engine := New()
+	engine.GET("/widgets/:id", mw, handlerForRoutesTest)
+
+	var found *RouteInfo
+	for _, route := range engine.Routes() {
+		if route.Path == "/widgets/:id" {
+			r := route
+			found = &r
+		}
+	}
+	if found == nil {
+		t.Fatal("expected /widgets/:id to be registered")
+	}
+	if found.Method != "GET" {
+		t.Fatalf("Method = %q, want GET", found.Method)
+	}
+	if found.HandlersCount != 2 {
+		t.Fatalf("HandlersCount = %d, want 2", found.HandlersCount)
+	}
+	if want := "github.com/HiLittleCat/goCore.handlerForRoutesTest"; found.HandlerName != want {
+		t.Fatalf("HandlerName = %q, want %q", found.HandlerName, want)
+	}
+}
+
+// handlerForRoutesTest is declared as a named package-level function (rather
+// than a closure) so its runtime.FuncForPC name is stable and predictable.
+func handlerForRoutesTest(c *Context) (interface{}, error) { return nil, nil }
+
+// TestDebugPrintRouteFuncFiresOnRegistration ensures the DebugPrintRouteFunc
+// hook, when set, is invoked once per registered route with the same
+// information exposed via Routes().
+func TestDebugPrintRouteFuncFiresOnRegistration(t *testing.T) {
+	defer func() { DebugPrintRouteFunc = nil }()
+
+	type call struct {
+		method, path, handlerName string
+		nuHandlers                int
+	}
+	var calls []call
+	DebugPrintRouteFunc = func(httpMethod, absolutePath, handlerName string, nuHandlers int) {
+		calls = append(calls, call{httpMethod, absolutePath, handlerName, nuHandlers})
+	}
+
+	engine := New()
+	engine.POST("/widgets", handlerForRoutesTest)
+
+	if len(calls) != 1 {
+		t.Fatalf("got %d DebugPrintRouteFunc calls, want 1", len(calls))
+	}
+	if calls[0].method != "POST" || calls[0].path != "/widgets" || calls[0].nuHandlers != 1 {
+		t.Fatalf("unexpected call recorded: %+v", calls[0])
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type greeterService struct{ greeting string }
+
+// TestHandleDIResolvesProvidedDependencies ensures a HandleDI handler's
+// extra parameters are resolved from the engine's provider registry and
+// passed through in order.
+func TestHandleDIResolvesProvidedDependencies(t *testing.T) {
+	engine := New()
+	engine.Provide(&greeterService{greeting: "hi"})
+	engine.Provide(func(c *Context) (string, error) { return c.Param("name"), nil })
+
+	engine.HandleDI(http.MethodGet, "/greet/:name", func(c *Context, svc *greeterService, name string) (interface{}, error) {
+		return svc.greeting + " " + name, nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/greet/ada", nil)
+	engine.ServeHTTP(w, req)
+
+	if got, want := w.Body.String(), "\"hi ada\"\n"; got != want {
+		t.Fatalf("body = %q, want %q", got, want)
+	}
+}
+
+// TestHandleDIPropagatesConstructorError ensures an error returned by a
+// func(*Context) (T, error) provider aborts the request with that error
+// rather than calling the handler.
+func TestHandleDIPropagatesConstructorError(t *testing.T) {
+	wantErr := errors.New("boom")
+	engine := New()
+	engine.Provide(func(c *Context) (string, error) { return "", wantErr })
+
+	var handlerRan bool
+	engine.HandleDI(http.MethodGet, "/broken", func(c *Context, dep string) (interface{}, error) {
+		handlerRan = true
+		return nil, nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/broken", nil)
+	engine.ServeHTTP(w, req)
+
+	if handlerRan {
+		t.Fatal("expected the handler not to run when a provider errors")
+	}
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func diHandlerForNameTest(c *Context, svc *greeterService) (interface{}, error) { return nil, nil }
+
+// TestHandleDIRecordsOriginalHandlerName ensures a route registered via
+// HandleDI reports the user's handler name in RouteInfo rather than the
+// internal diPlan.invoke wrapper that actually runs it.
+func TestHandleDIRecordsOriginalHandlerName(t *testing.T) {
+	engine := New()
+	engine.Provide(&greeterService{})
+	engine.HandleDI(http.MethodGet, "/named", diHandlerForNameTest)
+
+	var found *RouteInfo
+	for _, route := range engine.Routes() {
+		if route.Path == "/named" {
+			r := route
+			found = &r
+		}
+	}
+	if found == nil {
+		t.Fatal("expected /named to be registered")
+	}
+	if want := "github.com/HiLittleCat/goCore.diHandlerForNameTest"; found.HandlerName != want {
+		t.Fatalf("HandlerName = %q, want %q", found.HandlerName, want)
+	}
+}
+
+// TestHandleDIOnDetachedGroupRecordsOriginalHandlerName ensures the handler
+// name survives the NewGroup/Children pending-route path too, not just
+// direct registration against an attached engine.
+func TestHandleDIOnDetachedGroupRecordsOriginalHandlerName(t *testing.T) {
+	root := NewGroup("/api")
+	root.HandleDI(http.MethodGet, "/named", diHandlerForNameTest)
+
+	engine := New()
+	engine.Provide(&greeterService{})
+	engine.Register(root)
+
+	var found *RouteInfo
+	for _, route := range engine.Routes() {
+		if route.Path == "/api/named" {
+			r := route
+			found = &r
+		}
+	}
+	if found == nil {
+		t.Fatal("expected /api/named to be registered")
+	}
+	if want := "github.com/HiLittleCat/goCore.diHandlerForNameTest"; found.HandlerName != want {
+		t.Fatalf("HandlerName = %q, want %q", found.HandlerName, want)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+// TestEngineRegisterWalksChildTreeWithInheritance ensures Engine.Register
+// walks a NewGroup/Children tree depth-first, concatenating basePaths and
+// prepending each ancestor's middleware to every descendant route.
+func TestEngineRegisterWalksChildTreeWithInheritance(t *testing.T) {
+	var authRan bool
+	auth := func(c *Context) (interface{}, error) {
+		authRan = true
+		c.Next()
+		return nil, nil
+	}
+	h1 := func(c *Context) (interface{}, error) { return nil, nil }
+	h2 := func(c *Context) (interface{}, error) { return nil, nil }
+	h3 := func(c *Context) (interface{}, error) { return nil, nil }
+
+	root := NewGroup("/users/:id").GET("", h1).Middleware(auth).Children(
+		NewGroup("/posts").GET("", h2),
+		NewGroup("/posts/:pid").PUT("", h3).DELETE("", h3),
+	)
+
+	engine := New()
+	engine.Register(root)
+
+	var paths []string
+	for _, route := range engine.Routes() {
+		paths = append(paths, route.Method+" "+route.Path)
+	}
+	sort.Strings(paths)
+
+	want := []string{
+		"DELETE /users/:id/posts/:pid",
+		"GET /users/:id",
+		"GET /users/:id/posts",
+		"PUT /users/:id/posts/:pid",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("registered routes = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("registered routes = %v, want %v", paths, want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42/posts", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code/100 != 2 {
+		t.Fatalf("GET /users/42/posts status = %d, want 2xx", w.Code)
+	}
+	if !authRan {
+		t.Fatal("expected the auth middleware registered via Middleware to run for a child route")
+	}
+}
+
+// TestEngineRegisterBackfillsEngineOntoOriginalNode ensures that after
+// Register, the original *RouterGroup the caller holds (and its children)
+// remain usable, in particular Routes() must not panic with a nil engine.
+func TestEngineRegisterBackfillsEngineOntoOriginalNode(t *testing.T) {
+	h1 := func(c *Context) (interface{}, error) { return nil, nil }
+	root := NewGroup("/users/:id").GET("", h1)
+
+	engine := New()
+	engine.Register(root)
+
+	routes := root.Routes()
+	if len(routes) != 1 || routes[0].Path != "/users/:id" {
+		t.Fatalf("root.Routes() after Register = %v, want a single /users/:id route", routes)
+	}
+}
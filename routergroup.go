@@ -5,7 +5,12 @@
 package core
 
 import (
+	"net/http"
+	"path"
+	"reflect"
 	"regexp"
+	"runtime"
+	"strings"
 )
 
 // IRouter router interface
@@ -16,17 +21,23 @@ type IRouter interface {
 
 // IRoutes routes interface
 type IRoutes interface {
-	Use(...RouterHandler) IRoutes
+	Use(...RouterHandler) *RouterGroup
 
-	Handle(string, string, ...RouterHandler) IRoutes
-	Any(string, ...RouterHandler) IRoutes
-	GET(string, ...RouterHandler) IRoutes
-	POST(string, ...RouterHandler) IRoutes
-	DELETE(string, ...RouterHandler) IRoutes
-	PATCH(string, ...RouterHandler) IRoutes
-	PUT(string, ...RouterHandler) IRoutes
-	OPTIONS(string, ...RouterHandler) IRoutes
-	HEAD(string, ...RouterHandler) IRoutes
+	Handle(string, string, ...RouterHandler) *RouterGroup
+	Any(string, ...RouterHandler) *RouterGroup
+	Match([]string, string, ...RouterHandler) *RouterGroup
+	GET(string, ...RouterHandler) *RouterGroup
+	POST(string, ...RouterHandler) *RouterGroup
+	DELETE(string, ...RouterHandler) *RouterGroup
+	PATCH(string, ...RouterHandler) *RouterGroup
+	PUT(string, ...RouterHandler) *RouterGroup
+	OPTIONS(string, ...RouterHandler) *RouterGroup
+	HEAD(string, ...RouterHandler) *RouterGroup
+
+	StaticFile(string, string) *RouterGroup
+	StaticFileFS(string, string, http.FileSystem) *RouterGroup
+	Static(string, string) *RouterGroup
+	StaticFS(string, http.FileSystem) *RouterGroup
 }
 
 // RouterHandler http handler
@@ -35,6 +46,16 @@ type RouterHandler func(*Context) (interface{}, error)
 // RouterHandlerChain http handler array
 type RouterHandlerChain []RouterHandler
 
+// pendingRoute is a verb registration recorded against a detached RouterGroup
+// (one built with NewGroup) that has no engine yet. It is replayed by
+// Engine.Register once the group tree is attached.
+type pendingRoute struct {
+	method       string
+	relativePath string
+	handlers     RouterHandlerChain
+	handlerName  string
+}
+
 // RouterGroup is used internally to configure router, a RouterGroup is associated with a prefix
 // and an array of handlers (middleware).
 type RouterGroup struct {
@@ -42,18 +63,70 @@ type RouterGroup struct {
 	basePath string
 	engine   *Engine
 	root     bool
+
+	pending  []pendingRoute
+	children []*RouterGroup
 }
 
 var _ IRouter = &RouterGroup{}
 
-const abortIndex = 5
+// AnyMethods is the set of HTTP methods registered by Any. It is an exported
+// package-level variable rather than a hard-coded list so callers can add or
+// remove verbs (e.g. drop CONNECT/TRACE in security-sensitive deployments)
+// without forking the package.
+var AnyMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodTrace,
+}
+
+// httpMethodRegexp matches a valid, all-uppercase HTTP method name. It is
+// compiled once at package init so Handle does not pay a compile cost on
+// every call.
+var httpMethodRegexp = regexp.MustCompile("^[A-Z]+$")
+
+// NewGroup creates a detached RouterGroup rooted at relativePath. Unlike
+// Group, it is not associated with an Engine yet, so routes registered on it
+// (directly or via Children) are only recorded, not dispatched; call
+// Engine.Register on the root of the tree to attach and register them all at
+// once. This lets REST resource trees be declared up front instead of via
+// nested Group() closures.
+func NewGroup(relativePath string, handlers ...RouterHandler) *RouterGroup {
+	return &RouterGroup{
+		Handlers: handlers,
+		basePath: relativePath,
+	}
+}
 
 // Use adds middleware to the group, see example code in github.
-func (group *RouterGroup) Use(middleware ...RouterHandler) IRoutes {
+func (group *RouterGroup) Use(middleware ...RouterHandler) *RouterGroup {
 	group.Handlers = append(group.Handlers, middleware...)
 	return group.returnObj()
 }
 
+// Middleware is an alias for Use, read more naturally when declaring a
+// group tree with NewGroup and Children.
+func (group *RouterGroup) Middleware(middleware ...RouterHandler) *RouterGroup {
+	return group.Use(middleware...)
+}
+
+// Children attaches child route groups under this group. Each child inherits
+// this group's basePath (including any path parameters, e.g. /users/:id) and
+// its Handlers chain, then contributes its own path segment, middleware and
+// verb handlers on top. Children is meant to be used on a detached group
+// built with NewGroup; call Engine.Register on the root of the tree to walk
+// it and register every route.
+func (group *RouterGroup) Children(children ...*RouterGroup) *RouterGroup {
+	group.children = append(group.children, children...)
+	return group.returnObj()
+}
+
 // Group creates a new router group. You should add all the routes that have common middlwares or the same path prefix.
 // For example, all the routes that use a common middlware for authorization could be grouped.
 func (group *RouterGroup) Group(relativePath string, handlers ...RouterHandler) *RouterGroup {
@@ -69,13 +142,74 @@ func (group *RouterGroup) BasePath() string {
 	return group.basePath
 }
 
-func (group *RouterGroup) handle(httpMethod, relativePath string, handlers RouterHandlerChain) IRoutes {
+func (group *RouterGroup) handle(httpMethod, relativePath string, handlers RouterHandlerChain) *RouterGroup {
+	return group.handleNamed(httpMethod, relativePath, handlers, nameOfLastHandler(handlers))
+}
+
+// handleNamed is like handle but lets the caller supply the handler name
+// recorded in RouteInfo/DebugPrintRouteFunc explicitly, rather than deriving
+// it from the chain via nameOfLastHandler. HandleDI uses this so a DI
+// handler's route reports the user's original function name instead of the
+// internal diPlan.invoke wrapper that actually runs it.
+func (group *RouterGroup) handleNamed(httpMethod, relativePath string, handlers RouterHandlerChain, handlerName string) *RouterGroup {
+	if group.engine == nil {
+		group.pending = append(group.pending, pendingRoute{
+			method:       httpMethod,
+			relativePath: relativePath,
+			handlers:     handlers,
+			handlerName:  handlerName,
+		})
+		return group.returnObj()
+	}
+
 	absolutePath := group.calculateAbsolutePath(relativePath)
 	handlers = group.combineHandlers(handlers)
-	group.engine.addRoute(httpMethod, absolutePath, handlers)
+	group.engine.addRoute(httpMethod, absolutePath, handlers, handlerName)
 	return group.returnObj()
 }
 
+// nameOfLastHandler returns the fully-qualified function name of the last
+// handler in the chain, i.e. the real handler rather than its middleware.
+func nameOfLastHandler(handlers RouterHandlerChain) string {
+	if len(handlers) == 0 {
+		return ""
+	}
+	last := handlers[len(handlers)-1]
+	return runtime.FuncForPC(reflect.ValueOf(last).Pointer()).Name()
+}
+
+// Routes returns the subset of the engine's registered routes whose path
+// is this group's base path or a path below it. It returns nil for a group
+// that isn't attached to an engine yet, e.g. one built with NewGroup before
+// Engine.Register has run.
+func (group *RouterGroup) Routes() []RouteInfo {
+	if group.engine == nil {
+		return nil
+	}
+	all := group.engine.Routes()
+	routes := make([]RouteInfo, 0, len(all))
+	for _, route := range all {
+		if pathUnder(route.Path, group.basePath) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// pathUnder reports whether path equals prefix or descends from it, i.e.
+// prefix is followed by a path separator rather than falling in the middle
+// of a segment. This keeps a group at "/user" from matching a sibling
+// group's routes registered under "/users".
+func pathUnder(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if len(path) == len(prefix) || prefix == "" || prefix[len(prefix)-1] == '/' {
+		return true
+	}
+	return path[len(prefix)] == '/'
+}
+
 // Handle registers a new request handle and middleware with the given path and method.
 // The last handler should be the real handler, the other ones should be middleware that can and should be shared among different routes.
 // See the example code in github.
@@ -86,63 +220,133 @@ func (group *RouterGroup) handle(httpMethod, relativePath string, handlers Route
 // This function is intended for bulk loading and to allow the usage of less
 // frequently used, non-standardized or custom methods (e.g. for internal
 // communication with a proxy).
-func (group *RouterGroup) Handle(httpMethod, relativePath string, handlers ...RouterHandler) IRoutes {
-	if matches, err := regexp.MatchString("^[A-Z]+$", httpMethod); !matches || err != nil {
+func (group *RouterGroup) Handle(httpMethod, relativePath string, handlers ...RouterHandler) *RouterGroup {
+	if !httpMethodRegexp.MatchString(httpMethod) {
 		panic("http method " + httpMethod + " is not valid")
 	}
 	return group.handle(httpMethod, relativePath, handlers)
 }
 
 // POST is a shortcut for router.Handle("POST", path, handle).
-func (group *RouterGroup) POST(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) POST(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("POST", relativePath, handlers)
 }
 
 // GET is a shortcut for router.Handle("GET", path, handle).
-func (group *RouterGroup) GET(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) GET(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("GET", relativePath, handlers)
 }
 
 // DELETE is a shortcut for router.Handle("DELETE", path, handle).
-func (group *RouterGroup) DELETE(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) DELETE(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("DELETE", relativePath, handlers)
 }
 
 // PATCH is a shortcut for router.Handle("PATCH", path, handle).
-func (group *RouterGroup) PATCH(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) PATCH(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("PATCH", relativePath, handlers)
 }
 
 // PUT is a shortcut for router.Handle("PUT", path, handle).
-func (group *RouterGroup) PUT(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) PUT(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("PUT", relativePath, handlers)
 }
 
 // OPTIONS is a shortcut for router.Handle("OPTIONS", path, handle).
-func (group *RouterGroup) OPTIONS(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) OPTIONS(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("OPTIONS", relativePath, handlers)
 }
 
 // HEAD is a shortcut for router.Handle("HEAD", path, handle).
-func (group *RouterGroup) HEAD(relativePath string, handlers ...RouterHandler) IRoutes {
+func (group *RouterGroup) HEAD(relativePath string, handlers ...RouterHandler) *RouterGroup {
 	return group.handle("HEAD", relativePath, handlers)
 }
 
-// Any registers a route that matches all the HTTP methods.
-// GET, POST, PUT, PATCH, HEAD, OPTIONS, DELETE, CONNECT, TRACE.
-func (group *RouterGroup) Any(relativePath string, handlers ...RouterHandler) IRoutes {
-	group.handle("GET", relativePath, handlers)
-	group.handle("POST", relativePath, handlers)
-	group.handle("PUT", relativePath, handlers)
-	group.handle("PATCH", relativePath, handlers)
-	group.handle("HEAD", relativePath, handlers)
-	group.handle("OPTIONS", relativePath, handlers)
-	group.handle("DELETE", relativePath, handlers)
-	group.handle("CONNECT", relativePath, handlers)
-	group.handle("TRACE", relativePath, handlers)
+// Any registers a route that matches the methods in AnyMethods, which by
+// default is GET, POST, PUT, PATCH, HEAD, OPTIONS, DELETE, CONNECT, TRACE.
+// Replace the AnyMethods slice to change the method set package-wide.
+func (group *RouterGroup) Any(relativePath string, handlers ...RouterHandler) *RouterGroup {
+	return group.Match(AnyMethods, relativePath, handlers...)
+}
+
+// Match registers a route that matches the specified methods that you declared.
+func (group *RouterGroup) Match(methods []string, relativePath string, handlers ...RouterHandler) *RouterGroup {
+	for _, method := range methods {
+		group.handle(method, relativePath, handlers)
+	}
+	return group.returnObj()
+}
+
+// StaticFile registers a single route in order to serve a single file of the local filesystem.
+// router.StaticFile("favicon.ico", "./resources/favicon.ico")
+func (group *RouterGroup) StaticFile(relativePath, filepath string) *RouterGroup {
+	return group.staticFileHandler(relativePath, func(c *Context) (interface{}, error) {
+		c.File(filepath)
+		return nil, nil
+	})
+}
+
+// StaticFileFS works just like StaticFile but a custom http.FileSystem can be used instead,
+// so it is possible to serve files from an embed.FS via http.FS(fs).
+// router.StaticFileFS("favicon.ico", "favicon.ico", http.FS(embeddedFS))
+func (group *RouterGroup) StaticFileFS(relativePath, filepath string, fs http.FileSystem) *RouterGroup {
+	return group.staticFileHandler(relativePath, func(c *Context) (interface{}, error) {
+		c.FileFromFS(filepath, fs)
+		return nil, nil
+	})
+}
+
+func (group *RouterGroup) staticFileHandler(relativePath string, handler RouterHandler) *RouterGroup {
+	if strings.Contains(relativePath, ":") || strings.Contains(relativePath, "*") {
+		panic("URL parameters can not be used when serving a static file")
+	}
+	group.GET(relativePath, handler)
+	group.HEAD(relativePath, handler)
+	return group.returnObj()
+}
+
+// Static serves files from the given file system root.
+// Internally a http.FileServer is used. A request for a file that cannot be
+// opened gets a bare 404 status with an empty body; the Router's NotFound
+// handler is not used.
+// To use the operating system's file system implementation, use:
+//     router.Static("/static", "/var/www")
+func (group *RouterGroup) Static(relativePath, root string) *RouterGroup {
+	return group.StaticFS(relativePath, http.Dir(root))
+}
+
+// StaticFS works just like Static() but a custom http.FileSystem can be used instead,
+// so it is possible to serve files from an embed.FS via http.FS(fs).
+func (group *RouterGroup) StaticFS(relativePath string, fs http.FileSystem) *RouterGroup {
+	if strings.Contains(relativePath, ":") || strings.Contains(relativePath, "*") {
+		panic("URL parameters can not be used when serving a static folder")
+	}
+	handler := group.createStaticHandler(relativePath, fs)
+	urlPattern := path.Join(relativePath, "/*filepath")
+	group.GET(urlPattern, handler)
+	group.HEAD(urlPattern, handler)
 	return group.returnObj()
 }
 
+func (group *RouterGroup) createStaticHandler(relativePath string, fs http.FileSystem) RouterHandler {
+	absolutePath := group.calculateAbsolutePath(relativePath)
+	fileServer := http.StripPrefix(absolutePath, http.FileServer(fs))
+
+	return func(c *Context) (interface{}, error) {
+		file := c.Param("filepath")
+		// Check if file exists and/or if we have permission to access it
+		f, err := fs.Open(file)
+		if err != nil {
+			c.Writer.WriteHeader(http.StatusNotFound)
+			return nil, nil
+		}
+		f.Close()
+
+		fileServer.ServeHTTP(c.Writer, c.Request)
+		return nil, nil
+	}
+}
+
 func (group *RouterGroup) combineHandlers(handlers RouterHandlerChain) RouterHandlerChain {
 	finalSize := len(group.Handlers) + len(handlers)
 	if finalSize >= int(abortIndex) {
@@ -158,6 +362,6 @@ func (group *RouterGroup) calculateAbsolutePath(relativePath string) string {
 	return joinPaths(group.basePath, relativePath)
 }
 
-func (group *RouterGroup) returnObj() IRoutes {
+func (group *RouterGroup) returnObj() *RouterGroup {
 	return group
 }
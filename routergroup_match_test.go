@@ -0,0 +1,72 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+)
+
+// TestRouterGroupMatchRegistersOnlyGivenMethods ensures Match registers the
+// handler chain against exactly the caller-supplied verbs, not AnyMethods.
+func TestRouterGroupMatchRegistersOnlyGivenMethods(t *testing.T) {
+	engine := New()
+	handler := func(c *Context) (interface{}, error) { return nil, nil }
+
+	engine.Match([]string{http.MethodGet, http.MethodPost}, "/widgets", handler)
+
+	var got []string
+	for _, route := range engine.Routes() {
+		if route.Path == "/widgets" {
+			got = append(got, route.Method)
+		}
+	}
+	sort.Strings(got)
+
+	want := []string{http.MethodGet, http.MethodPost}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("methods registered for /widgets = %v, want %v", got, want)
+	}
+}
+
+// TestRouterGroupAnyHonorsAnyMethodsOverride ensures Any is driven by the
+// exported, mutable AnyMethods slice, so callers can opt out of verbs like
+// CONNECT/TRACE without forking the package.
+func TestRouterGroupAnyHonorsAnyMethodsOverride(t *testing.T) {
+	original := AnyMethods
+	AnyMethods = []string{http.MethodGet, http.MethodPost}
+	defer func() { AnyMethods = original }()
+
+	engine := New()
+	handler := func(c *Context) (interface{}, error) { return nil, nil }
+	engine.Any("/things", handler)
+
+	count := 0
+	for _, route := range engine.Routes() {
+		if route.Path == "/things" {
+			count++
+			if route.Method != http.MethodGet && route.Method != http.MethodPost {
+				t.Fatalf("unexpected method %q registered by Any after AnyMethods override", route.Method)
+			}
+		}
+	}
+	if count != 2 {
+		t.Fatalf("got %d routes registered by Any, want 2 (matching the overridden AnyMethods)", count)
+	}
+}
+
+// TestRouterGroupHandleRejectsInvalidMethod ensures Handle still validates
+// the HTTP method via the cached httpMethodRegexp.
+func TestRouterGroupHandleRejectsInvalidMethod(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Handle to panic on an invalid HTTP method")
+		}
+	}()
+
+	engine := New()
+	engine.Handle("get", "/lower", func(c *Context) (interface{}, error) { return nil, nil })
+}
@@ -0,0 +1,27 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import "testing"
+
+// TestRouterGroupRoutesExcludesSiblingPrefix ensures a group's Routes()
+// doesn't pick up a sibling group whose base path merely shares a string
+// prefix, e.g. "/user" vs. "/users".
+func TestRouterGroupRoutesExcludesSiblingPrefix(t *testing.T) {
+	engine := New()
+	handler := func(c *Context) (interface{}, error) { return nil, nil }
+
+	userGroup := engine.Group("/user")
+	userGroup.GET("/profile", handler)
+
+	usersGroup := engine.Group("/users")
+	usersGroup.GET("/list", handler)
+
+	for _, route := range userGroup.Routes() {
+		if route.Path == "/users/list" {
+			t.Fatalf("userGroup.Routes() unexpectedly included sibling route %q", route.Path)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+		t.Fatalf("WriteFile(%q): %v", p, err)
+	}
+	return p
+}
+
+// TestRouterGroupStaticFileServesSingleFile ensures StaticFile registers a
+// GET (and HEAD) route that serves exactly the file it was given.
+func TestRouterGroupStaticFileServesSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "favicon.ico", "icon-bytes")
+
+	engine := New()
+	engine.StaticFile("/favicon.ico", filepath.Join(dir, "favicon.ico"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/favicon.ico", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Body.String(); got != "icon-bytes" {
+		t.Fatalf("body = %q, want %q", got, "icon-bytes")
+	}
+}
+
+// TestRouterGroupStaticServesDirectory ensures Static serves files out of a
+// directory root under the registered catch-all path.
+func TestRouterGroupStaticServesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "hello.txt", "hello-static")
+
+	engine := New()
+	engine.Static("/assets", dir)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/assets/hello.txt", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Body.String(); got != "hello-static" {
+		t.Fatalf("body = %q, want %q", got, "hello-static")
+	}
+}
+
+// TestRouterGroupStaticFSMissingFileReturns404 ensures a missing file under
+// a static root produces 404 rather than falling through to the file server.
+func TestRouterGroupStaticFSMissingFileReturns404(t *testing.T) {
+	dir := t.TempDir()
+
+	engine := New()
+	engine.StaticFS("/assets", http.Dir(dir))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/assets/missing.txt", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// TestRouterGroupStaticRejectsWildcardPath ensures Static panics on a
+// parameterized relativePath since the catch-all is generated internally.
+func TestRouterGroupStaticRejectsWildcardPath(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Static to panic on a wildcard relativePath")
+		}
+	}()
+
+	New().Static("/assets/:id", t.TempDir())
+}